@@ -0,0 +1,41 @@
+package apexlog
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+var debugEnabled int32
+
+// SetDebug toggles package-wide debug instrumentation. While enabled,
+// every Tracer automatically attaches a "caller" field to each span's
+// initial log entry, and a "source" field to every subsequent LogFields or
+// LogKV call. This costs a runtime.Caller lookup per call, so it should
+// stay off in production; use WithAutoCaller to opt a single Tracer in
+// instead of flipping this global.
+func SetDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&debugEnabled, v)
+}
+
+// DebugEnabled reports whether SetDebug(true) is currently in effect.
+func DebugEnabled() bool {
+	return atomic.LoadInt32(&debugEnabled) == 1
+}
+
+// Caller captures the file:line of its caller, skip frames further up the
+// stack (0 meaning "whoever called Caller"), and returns it as an
+// otlog.String field named key.
+func Caller(key string, skip int) otlog.Field {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return otlog.String(key, "")
+	}
+	return otlog.String(key, fmt.Sprintf("%s:%d", file, line))
+}
@@ -0,0 +1,54 @@
+package apexlog
+
+import (
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/tj/assert"
+)
+
+func TestWithAutoCallerAddsCallerField(t *testing.T) {
+	h := memory.New()
+	tracer := New(&log.Logger{Handler: h}, WithAutoCaller(0))
+
+	span := tracer.StartSpan("op")
+	span.Finish()
+
+	entry := h.Entries[0]
+	assert.NotEqual(t, "", entry.Fields.Get("caller"))
+}
+
+func TestSetDebugAddsSourceField(t *testing.T) {
+	h := memory.New()
+	tracer := New(&log.Logger{Handler: h})
+
+	SetDebug(true)
+	defer SetDebug(false)
+
+	span := tracer.StartSpan("op")
+	span.LogKV("event", "thing")
+	span.Finish()
+
+	var found bool
+	for _, entry := range h.Entries {
+		if entry.Fields.Get("source") != nil {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestAutoCallerOffByDefault(t *testing.T) {
+	h := memory.New()
+	tracer := New(&log.Logger{Handler: h})
+
+	span := tracer.StartSpan("op")
+	span.LogFields()
+	span.Finish()
+
+	for _, entry := range h.Entries {
+		assert.Nil(t, entry.Fields.Get("caller"))
+		assert.Nil(t, entry.Fields.Get("source"))
+	}
+}
@@ -0,0 +1,62 @@
+package apexlog
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// causer is satisfied by any error that can report the error it wraps, per
+// the convention established by github.com/pkg/errors. Errorf produces
+// errors that implement it, and HasErr walks the chain it describes.
+type causer interface {
+	Cause() error
+}
+
+// tracedError wraps a cause with a message and the file:line of the caller
+// that raised it, so span errors carry enough context to find their origin
+// without needing a full stack trace.
+type tracedError struct {
+	cause   error
+	message string
+	file    string
+	line    int
+}
+
+func (e *tracedError) Error() string {
+	return fmt.Sprintf("%s: %s (%s:%d)", e.message, e.cause, e.file, e.line)
+}
+
+// Cause implements causer.
+func (e *tracedError) Cause() error {
+	return e.cause
+}
+
+// Errorf wraps cause with a formatted message and the file:line of its
+// caller, similar to github.com/pkg/errors.Wrapf. The returned error
+// satisfies causer, so it can be walked with HasErr.
+func Errorf(cause error, msg string, args ...interface{}) error {
+	_, file, line, _ := runtime.Caller(1)
+	return &tracedError{
+		cause:   cause,
+		message: fmt.Sprintf(msg, args...),
+		file:    file,
+		line:    line,
+	}
+}
+
+// HasErr walks err and its Cause() chain, calling predicate on each in
+// turn, and reports whether any of them satisfied it.
+func HasErr(err error, predicate func(error) bool) bool {
+	for err != nil {
+		if predicate(err) {
+			return true
+		}
+
+		c, ok := err.(causer)
+		if !ok {
+			return false
+		}
+		err = c.Cause()
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package apexlog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/tj/assert"
+)
+
+var errBoom = errors.New("boom")
+
+func TestErrorfWraps(t *testing.T) {
+	err := Errorf(errBoom, "while doing %s", "work")
+	assert.Equal(t, errBoom, err.(causer).Cause())
+}
+
+func TestHasErr(t *testing.T) {
+	wrapped := Errorf(errBoom, "while doing work")
+	assert.True(t, HasErr(wrapped, func(err error) bool { return err == errBoom }))
+	assert.False(t, HasErr(wrapped, func(err error) bool { return err == errors.New("other") }))
+}
+
+func TestSetErrorFinishesAtErrorLevel(t *testing.T) {
+	h := memory.New()
+	tracer := New(&log.Logger{Handler: h})
+
+	span := tracer.StartSpan("op").(*Span)
+	span.SetError(errBoom)
+	span.Finish()
+
+	entry := h.Entries[len(h.Entries)-1]
+	assert.Equal(t, log.ErrorLevel, entry.Level)
+	assert.Equal(t, errBoom.Error(), entry.Fields.Get("error"))
+}
+
+func TestLogKVErrorFinishesAtErrorLevel(t *testing.T) {
+	h := memory.New()
+	tracer := New(&log.Logger{Handler: h})
+
+	span := tracer.StartSpan("op")
+	span.LogKV("error", errBoom)
+	span.Finish()
+
+	entry := h.Entries[len(h.Entries)-1]
+	assert.Equal(t, log.ErrorLevel, entry.Level)
+	assert.Equal(t, errBoom.Error(), entry.Fields.Get("error"))
+}
@@ -0,0 +1,30 @@
+package apexlog
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// idSource generates random 64-bit trace and span identifiers. A single,
+// mutex-guarded *rand.Rand is used instead of math/rand's global source so
+// that ID generation doesn't contend with unrelated callers of math/rand.
+var idSource = struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}{
+	rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
+}
+
+// randomID returns a random, non-zero 64-bit identifier suitable for use as
+// a trace or span ID.
+func randomID() uint64 {
+	idSource.mu.Lock()
+	defer idSource.mu.Unlock()
+
+	id := idSource.rnd.Uint64()
+	for id == 0 {
+		id = idSource.rnd.Uint64()
+	}
+	return id
+}
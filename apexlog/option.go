@@ -5,7 +5,12 @@ const (
 )
 
 type config struct {
-	msgKey string
+	msgKey         string
+	injectors      map[interface{}]Injector
+	extractors     map[interface{}]Extractor
+	sampler        Sampler
+	autoCaller     bool
+	autoCallerSkip int
 }
 
 type Option func(c *config)
@@ -15,3 +20,39 @@ func WithMsgKey(msgKey string) Option {
 		c.msgKey = msgKey
 	}
 }
+
+// WithPropagator registers injector and extractor for format, overriding
+// the default propagator if one is already registered for it. This allows
+// third parties to add support for custom formats, or to replace the
+// built-in HTTPHeaders, TextMap, and Binary propagators.
+func WithPropagator(format interface{}, injector Injector, extractor Extractor) Option {
+	return func(c *config) {
+		if c.injectors == nil {
+			c.injectors = map[interface{}]Injector{}
+		}
+		if c.extractors == nil {
+			c.extractors = map[interface{}]Extractor{}
+		}
+		c.injectors[format] = injector
+		c.extractors[format] = extractor
+	}
+}
+
+// WithSampler sets the Sampler consulted when starting a root span.
+// Without this option, every root span is sampled (ConstSampler(true)).
+func WithSampler(sampler Sampler) Option {
+	return func(c *config) {
+		c.sampler = sampler
+	}
+}
+
+// WithAutoCaller opts this Tracer into the same "caller"/"source" field
+// instrumentation as SetDebug(true), without touching the package-wide
+// debug flag. skip lets callers that wrap StartSpan/LogFields/LogKV in
+// their own helper compensate for the extra stack frame.
+func WithAutoCaller(skip int) Option {
+	return func(c *config) {
+		c.autoCaller = true
+		c.autoCallerSkip = skip
+	}
+}
@@ -0,0 +1,200 @@
+package apexlog
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	traceIDHeader       = "ot-tracer-traceid"
+	spanIDHeader        = "ot-tracer-spanid"
+	sampledHeader       = "ot-tracer-sampled"
+	baggageHeaderPrefix = "ot-baggage-"
+)
+
+// Injector encodes a SpanContext into carrier, whose concrete type depends
+// on the format it was registered under via WithPropagator.
+type Injector interface {
+	Inject(sc SpanContext, carrier interface{}) error
+}
+
+// Extractor decodes a SpanContext from carrier, whose concrete type depends
+// on the format it was registered under via WithPropagator.
+type Extractor interface {
+	Extract(carrier interface{}) (SpanContext, error)
+}
+
+// textMapPropagator implements the opentracing.TextMap and
+// opentracing.HTTPHeaders formats, encoding the trace/span ID and sampling
+// decision as ot-tracer-* entries and baggage as ot-baggage-<key> entries.
+type textMapPropagator struct{}
+
+func (textMapPropagator) Inject(sc SpanContext, carrier interface{}) error {
+	writer, ok := carrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	writer.Set(traceIDHeader, strconv.FormatUint(sc.TraceID, 16))
+	writer.Set(spanIDHeader, strconv.FormatUint(sc.SpanID, 16))
+	writer.Set(sampledHeader, strconv.FormatBool(sc.Sampled))
+
+	for k, v := range sc.baggage {
+		writer.Set(baggageHeaderPrefix+k, v)
+	}
+
+	return nil
+}
+
+func (textMapPropagator) Extract(carrier interface{}) (SpanContext, error) {
+	reader, ok := carrier.(opentracing.TextMapReader)
+	if !ok {
+		return SpanContext{}, opentracing.ErrInvalidCarrier
+	}
+
+	var (
+		sc    SpanContext
+		found bool
+	)
+
+	err := reader.ForeachKey(func(key, value string) error {
+		lower := strings.ToLower(key)
+		switch lower {
+		case traceIDHeader:
+			id, err := strconv.ParseUint(value, 16, 64)
+			if err != nil {
+				return opentracing.ErrSpanContextCorrupted
+			}
+			sc.TraceID = id
+			found = true
+		case spanIDHeader:
+			id, err := strconv.ParseUint(value, 16, 64)
+			if err != nil {
+				return opentracing.ErrSpanContextCorrupted
+			}
+			sc.SpanID = id
+		case sampledHeader:
+			sampled, err := strconv.ParseBool(value)
+			if err != nil {
+				return opentracing.ErrSpanContextCorrupted
+			}
+			sc.Sampled = sampled
+		default:
+			if strings.HasPrefix(lower, baggageHeaderPrefix) {
+				sc = sc.withBaggageItem(lower[len(baggageHeaderPrefix):], value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return SpanContext{}, err
+	}
+	if !found {
+		return SpanContext{}, opentracing.ErrSpanContextNotFound
+	}
+
+	return sc, nil
+}
+
+// binaryPropagator implements the opentracing.Binary format, writing to an
+// io.Writer carrier and reading from an io.Reader carrier.
+type binaryPropagator struct{}
+
+func (binaryPropagator) Inject(sc SpanContext, carrier interface{}) error {
+	writer, ok := carrier.(io.Writer)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	var sampled uint8
+	if sc.Sampled {
+		sampled = 1
+	}
+
+	header := make([]byte, 17)
+	binary.BigEndian.PutUint64(header[0:8], sc.TraceID)
+	binary.BigEndian.PutUint64(header[8:16], sc.SpanID)
+	header[16] = sampled
+	if _, err := writer.Write(header); err != nil {
+		return err
+	}
+
+	if err := binary.Write(writer, binary.BigEndian, uint32(len(sc.baggage))); err != nil {
+		return err
+	}
+	for k, v := range sc.baggage {
+		if err := writeBinaryString(writer, k); err != nil {
+			return err
+		}
+		if err := writeBinaryString(writer, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (binaryPropagator) Extract(carrier interface{}) (SpanContext, error) {
+	reader, ok := carrier.(io.Reader)
+	if !ok {
+		return SpanContext{}, opentracing.ErrInvalidCarrier
+	}
+
+	header := make([]byte, 17)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return SpanContext{}, opentracing.ErrSpanContextNotFound
+		}
+		return SpanContext{}, err
+	}
+
+	sc := SpanContext{
+		TraceID: binary.BigEndian.Uint64(header[0:8]),
+		SpanID:  binary.BigEndian.Uint64(header[8:16]),
+		Sampled: header[16] == 1,
+	}
+
+	var count uint32
+	if err := binary.Read(reader, binary.BigEndian, &count); err != nil {
+		return SpanContext{}, opentracing.ErrSpanContextCorrupted
+	}
+
+	for i := uint32(0); i < count; i++ {
+		key, err := readBinaryString(reader)
+		if err != nil {
+			return SpanContext{}, opentracing.ErrSpanContextCorrupted
+		}
+		value, err := readBinaryString(reader)
+		if err != nil {
+			return SpanContext{}, opentracing.ErrSpanContextCorrupted
+		}
+		sc = sc.withBaggageItem(key, value)
+	}
+
+	return sc, nil
+}
+
+func writeBinaryString(w io.Writer, s string) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func readBinaryString(r io.Reader) (string, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
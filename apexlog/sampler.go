@@ -0,0 +1,96 @@
+package apexlog
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Sampler decides whether a root span for the given traceID and operation
+// should be sampled (i.e. actually recorded), and supplies the tags (e.g.
+// sampler.type and sampler.param) that describe how the decision was made.
+// The decision is only ever consulted for root spans; child spans inherit
+// it from their parent's SpanContext.
+type Sampler interface {
+	ShouldSample(traceID uint64, operation string) (sampled bool, tags map[string]interface{})
+}
+
+// ConstSampler always returns the same sampling decision.
+type ConstSampler bool
+
+// ShouldSample implements Sampler.
+func (s ConstSampler) ShouldSample(traceID uint64, operation string) (bool, map[string]interface{}) {
+	return bool(s), map[string]interface{}{
+		"sampler.type":  "const",
+		"sampler.param": bool(s),
+	}
+}
+
+// ProbabilisticSampler samples traceID-based on a sampling rate between 0
+// (sample nothing) and 1 (sample everything).
+type ProbabilisticSampler float64
+
+// ShouldSample implements Sampler.
+func (s ProbabilisticSampler) ShouldSample(traceID uint64, operation string) (bool, map[string]interface{}) {
+	tags := map[string]interface{}{
+		"sampler.type":  "probabilistic",
+		"sampler.param": float64(s),
+	}
+
+	switch {
+	case s >= 1:
+		return true, tags
+	case s <= 0:
+		return false, tags
+	}
+
+	// math.MaxUint64 doesn't round-trip through float64 (it rounds up to
+	// 2^64, which overflows back to the platform-defined value on
+	// conversion to uint64), so compute the boundary from MaxInt64 instead
+	// and shift it up, as jaeger-client-go does.
+	boundary := uint64(float64(math.MaxInt64)*float64(s)) << 1
+	return traceID <= boundary, tags
+}
+
+// RateLimitingSampler samples at most maxTracesPerSecond new traces per
+// second, using a token bucket that refills continuously over time.
+type RateLimitingSampler struct {
+	maxTracesPerSecond float64
+
+	mu       sync.Mutex
+	balance  float64
+	lastTick time.Time
+}
+
+// NewRateLimitingSampler returns a Sampler that admits up to
+// maxTracesPerSecond new traces per second.
+func NewRateLimitingSampler(maxTracesPerSecond float64) *RateLimitingSampler {
+	return &RateLimitingSampler{
+		maxTracesPerSecond: maxTracesPerSecond,
+		balance:            maxTracesPerSecond,
+		lastTick:           time.Now(),
+	}
+}
+
+// ShouldSample implements Sampler.
+func (s *RateLimitingSampler) ShouldSample(traceID uint64, operation string) (bool, map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.balance += now.Sub(s.lastTick).Seconds() * s.maxTracesPerSecond
+	if s.balance > s.maxTracesPerSecond {
+		s.balance = s.maxTracesPerSecond
+	}
+	s.lastTick = now
+
+	sampled := s.balance >= 1
+	if sampled {
+		s.balance--
+	}
+
+	return sampled, map[string]interface{}{
+		"sampler.type":  "ratelimiting",
+		"sampler.param": s.maxTracesPerSecond,
+	}
+}
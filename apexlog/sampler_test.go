@@ -0,0 +1,42 @@
+package apexlog
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestProbabilisticSamplerFullRateSamplesEverything(t *testing.T) {
+	sampler := ProbabilisticSampler(1)
+
+	for _, traceID := range []uint64{0, 1, math.MaxInt64, math.MaxUint64} {
+		sampled, _ := sampler.ShouldSample(traceID, "op")
+		assert.True(t, sampled)
+	}
+}
+
+func TestProbabilisticSamplerZeroRateSamplesNothing(t *testing.T) {
+	sampler := ProbabilisticSampler(0)
+
+	for _, traceID := range []uint64{0, 1, math.MaxInt64, math.MaxUint64} {
+		sampled, _ := sampler.ShouldSample(traceID, "op")
+		assert.False(t, sampled)
+	}
+}
+
+func TestProbabilisticSamplerApproximatesRate(t *testing.T) {
+	sampler := ProbabilisticSampler(0.5)
+
+	const trials = 200000
+	var sampled int
+	for i := 0; i < trials; i++ {
+		ok, _ := sampler.ShouldSample(randomID(), "op")
+		if ok {
+			sampled++
+		}
+	}
+
+	ratio := float64(sampled) / trials
+	assert.True(t, ratio > 0.49 && ratio < 0.51)
+}
@@ -14,11 +14,28 @@ type Span struct {
 	startedAt     time.Time
 	logger        *log.Entry
 
+	traceID  uint64
+	spanID   uint64
+	parentID uint64
+	sampled  bool
+	err      error
+
 	// mutex protects the following
 	baggage map[string]string
 	tags    map[string]interface{}
 }
 
+// context returns the SpanContext snapshot of this Span's identity and
+// baggage, suitable for Tracer.Inject or for use as a ChildOf reference.
+func (s *Span) context() SpanContext {
+	return SpanContext{
+		TraceID: s.traceID,
+		SpanID:  s.spanID,
+		Sampled: s.sampled,
+		baggage: s.baggage,
+	}
+}
+
 // ForeachBaggageItem grants access to all baggage items stored in the
 // SpanContext.
 // The handler function will be called for each baggage key/value pair.
@@ -41,17 +58,55 @@ func (s *Span) ForeachBaggageItem(handler func(k, v string) bool) {
 // With the exception of calls to Context() (which are always allowed),
 // Finish() must be the last call made to any span instance, and to do
 // otherwise leads to undefined behavior.
+//
+// If SetError was called, or a log.Field with key "error.object" or
+// "error" was logged on this span, the entry is finalized at Error level
+// instead, carrying the recorded error.
 func (s *Span) Finish() {
-	s.logger.Stop(nil)
+	s.finish()
 }
 
 // FinishWithOptions is like Finish() but with explicit control over
 // timestamps and log data.
 func (s *Span) FinishWithOptions(opts opentracing.FinishOptions) {
 	for _, record := range opts.LogRecords {
+		s.recordErrFields(record.Fields)
 		s.tracer.info(s.logger, s.baggage, s.tags, record.Fields...)
 	}
-	s.logger.Stop(nil)
+	s.finish()
+}
+
+func (s *Span) finish() {
+	if s.err == nil {
+		s.logger.Stop(nil)
+		return
+	}
+
+	err := s.err
+	s.logger.Stop(&err)
+}
+
+// SetError records err on the span so Finish/FinishWithOptions finalize
+// the log entry at Error level, and tags the span with "error" per
+// https://github.com/opentracing/specification/blob/master/semantic_conventions.md#span-tags-table.
+func (s *Span) SetError(err error) opentracing.Span {
+	s.err = err
+	return s.SetTag("error", err != nil)
+}
+
+// recordErrFields inspects fields for the OpenTracing-conventional
+// "error.object" key (as produced by otlog.Error) or a plain "error" key,
+// and records the error so Finish can log it, unless an error is already
+// recorded (by SetError or by an earlier error field).
+func (s *Span) recordErrFields(fields []otlog.Field) {
+	for _, field := range fields {
+		switch field.Key() {
+		case "error.object", "error":
+			if err, ok := field.Value().(error); ok && s.err == nil {
+				s.err = err
+			}
+		}
+	}
 }
 
 // Context() yields the SpanContext for this Span. Note that the return
@@ -87,13 +142,25 @@ func (s *Span) SetTag(key string, value interface{}) opentracing.Span {
 // logging data about a Span, though the programming interface is a little
 // more verbose than LogKV(). Here's an example:
 //
-//    span.LogFields(
-//        log.String("event", "soft error"),
-//        log.String("type", "cache timeout"),
-//        log.Int("waited.millis", 1500))
+//	span.LogFields(
+//	    log.String("event", "soft error"),
+//	    log.String("type", "cache timeout"),
+//	    log.Int("waited.millis", 1500))
 //
 // Also see Span.FinishWithOptions() and FinishOptions.BulkLogData.
 func (s *Span) LogFields(fields ...otlog.Field) {
+	if s.tracer.autoCallerEnabled() {
+		fields = append(fields, Caller("source", 1+s.tracer.autoCallerSkip))
+	}
+	s.log(fields...)
+}
+
+// log records fields against the span's logger, first checking them for
+// OpenTracing-conventional error fields. It's shared by LogFields and
+// LogKV, both of which attach their own "source" field (when enabled)
+// before calling it, so the reported caller is the right one in either case.
+func (s *Span) log(fields ...otlog.Field) {
+	s.recordErrFields(fields)
 	s.tracer.info(s.logger, s.baggage, s.tags, fields...)
 }
 
@@ -101,15 +168,15 @@ func (s *Span) LogFields(fields ...otlog.Field) {
 // a Span, though unfortunately this also makes it less efficient and less
 // type-safe than LogFields(). Here's an example:
 //
-//    span.LogKV(
-//        "event", "soft error",
-//        "type", "cache timeout",
-//        "waited.millis", 1500)
+//	span.LogKV(
+//	    "event", "soft error",
+//	    "type", "cache timeout",
+//	    "waited.millis", 1500)
 //
 // For LogKV (as opposed to LogFields()), the parameters must appear as
 // key-value pairs, like
 //
-//    span.LogKV(key1, val1, key2, val2, key3, val3, ...)
+//	span.LogKV(key1, val1, key2, val2, key3, val3, ...)
 //
 // The keys must all be strings. The values may be strings, numeric types,
 // bools, Go error instances, or arbitrary structs.
@@ -148,6 +215,11 @@ func (s *Span) LogKV(alternatingKeyValues ...interface{}) {
 			fields = append(fields, otlog.Object(key, value))
 		}
 	}
+
+	if s.tracer.autoCallerEnabled() {
+		fields = append(fields, Caller("source", 1+s.tracer.autoCallerSkip))
+	}
+	s.log(fields...)
 }
 
 // SetBaggageItem sets a key:value pair on this Span and its SpanContext
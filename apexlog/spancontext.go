@@ -0,0 +1,56 @@
+package apexlog
+
+import "github.com/opentracing/opentracing-go"
+
+// SpanContext is a lightweight, immutable snapshot of a Span's identity and
+// baggage. Unlike *Span, it carries no reference to the apex/log logger, so
+// it's safe to pass across process boundaries via Tracer.Inject/Extract and
+// to hold on to after the originating Span has finished.
+type SpanContext struct {
+	TraceID uint64
+	SpanID  uint64
+	Sampled bool
+
+	baggage map[string]string
+}
+
+var _ opentracing.SpanContext = SpanContext{}
+
+// ForeachBaggageItem grants access to all baggage items stored in the
+// SpanContext.
+//
+// The handler function will be called for each baggage key/value pair. The
+// ordering of items is not guaranteed. If the handler returns false,
+// iteration stops.
+func (c SpanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+// withBaggageItem returns a copy of c with restrictedKey set to value.
+func (c SpanContext) withBaggageItem(restrictedKey, value string) SpanContext {
+	baggage := make(map[string]string, len(c.baggage)+1)
+	for k, v := range c.baggage {
+		baggage[k] = v
+	}
+	baggage[restrictedKey] = value
+	c.baggage = baggage
+	return c
+}
+
+// toSpanContext normalizes either a *Span (a local, in-process parent) or a
+// SpanContext (typically the result of a prior Extract) into a SpanContext,
+// so StartSpan can treat both uniformly.
+func toSpanContext(sc opentracing.SpanContext) (SpanContext, bool) {
+	switch v := sc.(type) {
+	case *Span:
+		return v.context(), true
+	case SpanContext:
+		return v, true
+	default:
+		return SpanContext{}, false
+	}
+}
@@ -1,16 +1,33 @@
 package apexlog
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/apex/log"
+	"github.com/apex/log/handlers/discard"
 	"github.com/opentracing/opentracing-go"
 	otlog "github.com/opentracing/opentracing-go/log"
 )
 
 type Tracer struct {
-	logger log.Interface
-	msgKey string
+	logger        log.Interface
+	discardLogger log.Interface
+	msgKey        string
+	sampler       Sampler
+
+	autoCaller     bool
+	autoCallerSkip int
+
+	injectors  map[interface{}]Injector
+	extractors map[interface{}]Extractor
+}
+
+// autoCallerEnabled reports whether this Tracer should attach "caller"/
+// "source" fields, either because it was built WithAutoCaller or because
+// the package-wide SetDebug(true) is in effect.
+func (t *Tracer) autoCallerEnabled() bool {
+	return t.autoCaller || DebugEnabled()
 }
 
 func (t *Tracer) makeFields(baggage map[string]string, tags map[string]interface{}, fields ...otlog.Field) (string, log.Fields) {
@@ -60,24 +77,23 @@ func (t *Tracer) info(logger log.Interface, baggage map[string]string, tags map[
 //
 // Examples:
 //
-//     var tracer opentracing.Tracer = ...
+//	var tracer opentracing.Tracer = ...
 //
-//     // The root-span case:
-//     sp := tracer.StartSpan("GetFeed")
+//	// The root-span case:
+//	sp := tracer.StartSpan("GetFeed")
 //
-//     // The vanilla child span case:
-//     sp := tracer.StartSpan(
-//         "GetFeed",
-//         opentracing.ChildOf(parentSpan.Context()))
-//
-//     // All the bells and whistles:
-//     sp := tracer.StartSpan(
-//         "GetFeed",
-//         opentracing.ChildOf(parentSpan.Context()),
-//         opentracing.Tag{"user_agent", loggedReq.UserAgent},
-//         opentracing.StartTime(loggedReq.Timestamp),
-//     )
+//	// The vanilla child span case:
+//	sp := tracer.StartSpan(
+//	    "GetFeed",
+//	    opentracing.ChildOf(parentSpan.Context()))
 //
+//	// All the bells and whistles:
+//	sp := tracer.StartSpan(
+//	    "GetFeed",
+//	    opentracing.ChildOf(parentSpan.Context()),
+//	    opentracing.Tag{"user_agent", loggedReq.UserAgent},
+//	    opentracing.StartTime(loggedReq.Timestamp),
+//	)
 func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
 	options := &opentracing.StartSpanOptions{}
 	for _, opt := range opts {
@@ -85,15 +101,22 @@ func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOp
 	}
 
 	var (
-		parent *Span
-		tags   = map[string]interface{}{}
+		parent    *Span
+		parentCtx SpanContext
+		hasParent bool
+		tags      = map[string]interface{}{}
 	)
 
 	for _, ref := range options.References {
-		if ref.Type == opentracing.ChildOfRef {
-			if v, ok := ref.ReferencedContext.(*Span); ok {
-				parent = v
-			}
+		if ref.Type != opentracing.ChildOfRef && ref.Type != opentracing.FollowsFromRef {
+			continue
+		}
+		if v, ok := ref.ReferencedContext.(*Span); ok {
+			parent = v
+		}
+		if ctx, ok := toSpanContext(ref.ReferencedContext); ok {
+			parentCtx = ctx
+			hasParent = true
 		}
 	}
 
@@ -101,19 +124,49 @@ func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOp
 		tracer:        t,
 		operationName: operationName,
 		startedAt:     time.Now(),
+		spanID:        randomID(),
 	}
 
-	if parent != nil {
-		for k, v := range parent.baggage {
+	if hasParent {
+		span.traceID = parentCtx.TraceID
+		span.parentID = parentCtx.SpanID
+		span.sampled = parentCtx.Sampled
+		for k, v := range parentCtx.baggage {
 			span.SetBaggageItem(k, v)
 		}
+	} else {
+		span.traceID = randomID()
+
+		var samplerTags map[string]interface{}
+		span.sampled, samplerTags = t.sampler.ShouldSample(span.traceID, operationName)
+		for k, v := range samplerTags {
+			tags[k] = v
+		}
+	}
+
+	if !span.sampled {
+		// Skip makeFields and the real logger entirely: an unsampled span
+		// should cost as little as possible on the hot path.
+		span.logger = t.discardLogger.Trace(operationName)
+		return span
 	}
 
 	for k, v := range options.Tags {
 		tags[k] = v
 	}
 
-	_, f := t.makeFields(span.baggage, tags)
+	idFields := []otlog.Field{
+		otlog.String("trace_id", strconv.FormatUint(span.traceID, 16)),
+		otlog.String("span_id", strconv.FormatUint(span.spanID, 16)),
+	}
+	if hasParent {
+		idFields = append(idFields, otlog.String("parent_id", strconv.FormatUint(span.parentID, 16)))
+	}
+	if t.autoCallerEnabled() {
+		idFields = append(idFields, Caller("caller", 1+t.autoCallerSkip))
+	}
+
+	_, f := t.makeFields(span.baggage, tags, idFields...)
 	if parent == nil {
 		span.logger = t.logger.WithFields(f).Trace(operationName)
 	} else {
@@ -136,11 +189,11 @@ func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOp
 //
 // Example usage (sans error handling):
 //
-//     carrier := opentracing.HTTPHeadersCarrier(httpReq.Header)
-//     err := tracer.Inject(
-//         span.Context(),
-//         opentracing.HTTPHeaders,
-//         carrier)
+//	carrier := opentracing.HTTPHeadersCarrier(httpReq.Header)
+//	err := tracer.Inject(
+//	    span.Context(),
+//	    opentracing.HTTPHeaders,
+//	    carrier)
 //
 // NOTE: All opentracing.Tracer implementations MUST support all
 // BuiltinFormats.
@@ -154,7 +207,17 @@ func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOp
 //
 // See Tracer.Extract().
 func (t *Tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
-	return opentracing.ErrUnsupportedFormat
+	sc, ok := toSpanContext(sm)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+
+	injector, ok := t.injectors[format]
+	if !ok {
+		return opentracing.ErrUnsupportedFormat
+	}
+
+	return injector.Inject(sc, carrier)
 }
 
 // Extract() returns a SpanContext instance given `format` and `carrier`.
@@ -168,43 +231,51 @@ func (t *Tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier
 //
 // Example usage (with StartSpan):
 //
+//	carrier := opentracing.HTTPHeadersCarrier(httpReq.Header)
+//	clientContext, err := tracer.Extract(opentracing.HTTPHeaders, carrier)
 //
-//     carrier := opentracing.HTTPHeadersCarrier(httpReq.Header)
-//     clientContext, err := tracer.Extract(opentracing.HTTPHeaders, carrier)
-//
-//     // ... assuming the ultimate goal here is to resume the trace with a
-//     // server-side Span:
-//     var serverSpan opentracing.Span
-//     if err == nil {
-//         span = tracer.StartSpan(
-//             rpcMethodName, ext.RPCServerOption(clientContext))
-//     } else {
-//         span = tracer.StartSpan(rpcMethodName)
-//     }
-//
+//	// ... assuming the ultimate goal here is to resume the trace with a
+//	// server-side Span:
+//	var serverSpan opentracing.Span
+//	if err == nil {
+//	    span = tracer.StartSpan(
+//	        rpcMethodName, ext.RPCServerOption(clientContext))
+//	} else {
+//	    span = tracer.StartSpan(rpcMethodName)
+//	}
 //
 // NOTE: All opentracing.Tracer implementations MUST support all
 // BuiltinFormats.
 //
 // Return values:
-//  - A successful Extract returns a SpanContext instance and a nil error
-//  - If there was simply no SpanContext to extract in `carrier`, Extract()
-//    returns (nil, opentracing.ErrSpanContextNotFound)
-//  - If `format` is unsupported or unrecognized, Extract() returns (nil,
-//    opentracing.ErrUnsupportedFormat)
-//  - If there are more fundamental problems with the `carrier` object,
-//    Extract() may return opentracing.ErrInvalidCarrier,
-//    opentracing.ErrSpanContextCorrupted, or implementation-specific
-//    errors.
+//   - A successful Extract returns a SpanContext instance and a nil error
+//   - If there was simply no SpanContext to extract in `carrier`, Extract()
+//     returns (nil, opentracing.ErrSpanContextNotFound)
+//   - If `format` is unsupported or unrecognized, Extract() returns (nil,
+//     opentracing.ErrUnsupportedFormat)
+//   - If there are more fundamental problems with the `carrier` object,
+//     Extract() may return opentracing.ErrInvalidCarrier,
+//     opentracing.ErrSpanContextCorrupted, or implementation-specific
+//     errors.
 //
 // See Tracer.Inject().
 func (t *Tracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
-	return nil, opentracing.ErrUnsupportedFormat
+	extractor, ok := t.extractors[format]
+	if !ok {
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+
+	sc, err := extractor.Extract(carrier)
+	if err != nil {
+		return nil, err
+	}
+	return sc, nil
 }
 
 func New(logger log.Interface, options ...Option) *Tracer {
 	c := &config{
-		msgKey: DefaultMsgKey,
+		msgKey:  DefaultMsgKey,
+		sampler: ConstSampler(true),
 	}
 	for _, opt := range options {
 		opt(c)
@@ -214,8 +285,33 @@ func New(logger log.Interface, options ...Option) *Tracer {
 		logger = log.Log
 	}
 
-	return &Tracer{
-		logger: logger,
-		msgKey: c.msgKey,
+	tracer := &Tracer{
+		logger:         logger,
+		discardLogger:  &log.Logger{Handler: discard.Default},
+		msgKey:         c.msgKey,
+		sampler:        c.sampler,
+		autoCaller:     c.autoCaller,
+		autoCallerSkip: c.autoCallerSkip,
+		injectors:      map[interface{}]Injector{},
+		extractors:     map[interface{}]Extractor{},
 	}
+
+	textMap := textMapPropagator{}
+	tracer.injectors[opentracing.HTTPHeaders] = textMap
+	tracer.extractors[opentracing.HTTPHeaders] = textMap
+	tracer.injectors[opentracing.TextMap] = textMap
+	tracer.extractors[opentracing.TextMap] = textMap
+
+	binary := binaryPropagator{}
+	tracer.injectors[opentracing.Binary] = binary
+	tracer.extractors[opentracing.Binary] = binary
+
+	for format, injector := range c.injectors {
+		tracer.injectors[format] = injector
+	}
+	for format, extractor := range c.extractors {
+		tracer.extractors[format] = extractor
+	}
+
+	return tracer
 }
@@ -1,6 +1,8 @@
 package apexlog
 
 import (
+	"bytes"
+	"net/http"
 	"testing"
 
 	"github.com/opentracing/opentracing-go"
@@ -22,3 +24,88 @@ func TestParentChild(t *testing.T) {
 	child.Finish()
 	parent.Finish()
 }
+
+func TestInjectExtractHTTPHeaders(t *testing.T) {
+	tracer := New(nil)
+	parent := tracer.StartSpan("parent")
+	parent.SetBaggageItem("bk", "bv")
+
+	header := http.Header{}
+	carrier := opentracing.HTTPHeadersCarrier(header)
+	err := tracer.Inject(parent.Context(), opentracing.HTTPHeaders, carrier)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", header.Get("ot-tracer-traceid"))
+
+	sc, err := tracer.Extract(opentracing.HTTPHeaders, carrier)
+	assert.Nil(t, err)
+	assert.Equal(t, "bv", sc.(SpanContext).baggage["bk"])
+
+	child := tracer.StartSpan("child", opentracing.ChildOf(sc))
+	child.Finish()
+	parent.Finish()
+}
+
+func TestInjectExtractTextMap(t *testing.T) {
+	tracer := New(nil)
+	parent := tracer.StartSpan("parent")
+
+	carrier := opentracing.TextMapCarrier{}
+	err := tracer.Inject(parent.Context(), opentracing.TextMap, carrier)
+	assert.Nil(t, err)
+
+	sc, err := tracer.Extract(opentracing.TextMap, carrier)
+	assert.Nil(t, err)
+	assert.Equal(t, parent.(*Span).traceID, sc.(SpanContext).TraceID)
+
+	parent.Finish()
+}
+
+func TestInjectExtractBinary(t *testing.T) {
+	tracer := New(nil)
+	parent := tracer.StartSpan("parent")
+	parent.SetBaggageItem("bk", "bv")
+
+	var buf bytes.Buffer
+	err := tracer.Inject(parent.Context(), opentracing.Binary, &buf)
+	assert.Nil(t, err)
+
+	sc, err := tracer.Extract(opentracing.Binary, &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, parent.(*Span).traceID, sc.(SpanContext).TraceID)
+	assert.Equal(t, parent.(*Span).spanID, sc.(SpanContext).SpanID)
+	assert.Equal(t, "bv", sc.(SpanContext).baggage["bk"])
+
+	child := tracer.StartSpan("child", opentracing.ChildOf(sc))
+	child.Finish()
+	parent.Finish()
+}
+
+func TestExtractMissingContext(t *testing.T) {
+	tracer := New(nil)
+	_, err := tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier{})
+	assert.Equal(t, opentracing.ErrSpanContextNotFound, err)
+}
+
+func TestSamplerUnsampledSkipsChildLogic(t *testing.T) {
+	tracer := New(nil, WithSampler(ConstSampler(false)))
+
+	parent := tracer.StartSpan("parent")
+	assert.False(t, parent.(*Span).sampled)
+
+	child := tracer.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	assert.False(t, child.(*Span).sampled)
+
+	child.Finish()
+	parent.Finish()
+}
+
+func TestSamplerPropagatesOverInject(t *testing.T) {
+	tracer := New(nil, WithSampler(ConstSampler(true)))
+	parent := tracer.StartSpan("parent")
+
+	carrier := opentracing.TextMapCarrier{}
+	assert.Nil(t, tracer.Inject(parent.Context(), opentracing.TextMap, carrier))
+	assert.Equal(t, "true", carrier["ot-tracer-sampled"])
+
+	parent.Finish()
+}
@@ -0,0 +1,125 @@
+// Package multi provides an opentracing.Tracer that fans out every
+// operation to a set of underlying tracers, so a service can, for example,
+// send spans to apexlog for local structured logs and to Jaeger or Zipkin
+// at the same time.
+package multi
+
+import (
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// Multi returns an opentracing.Tracer that forwards every StartSpan and
+// Inject call to each of tracers, in order, and tries Extract on each in
+// turn until one succeeds. The Span and
+// SpanContext it produces are themselves composites that dispatch to the
+// corresponding Span/SpanContext of each underlying tracer.
+func Multi(tracers ...opentracing.Tracer) opentracing.Tracer {
+	return &tracer{tracers: tracers}
+}
+
+type tracer struct {
+	tracers []opentracing.Tracer
+}
+
+func (t *tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	options := opentracing.StartSpanOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	spans := make([]opentracing.Span, len(t.tracers))
+	for i, tr := range t.tracers {
+		spans[i] = tr.StartSpan(operationName, perTracerOptions(options, i)...)
+	}
+
+	return &span{tracer: t, spans: spans}
+}
+
+// perTracerOptions rewrites options so that any reference to a composite
+// SpanContext is replaced with the i'th underlying tracer's own context,
+// ensuring each backend sees a context it created itself.
+func perTracerOptions(options opentracing.StartSpanOptions, i int) []opentracing.StartSpanOption {
+	opts := make([]opentracing.StartSpanOption, 0, len(options.References)+len(options.Tags)+1)
+
+	for _, ref := range options.References {
+		ctx, ok := ref.ReferencedContext.(*spanContext)
+		if !ok {
+			opts = append(opts, ref)
+			continue
+		}
+		if child := ctx.contexts[i]; child != nil {
+			opts = append(opts, opentracing.SpanReference{Type: ref.Type, ReferencedContext: child})
+		}
+	}
+
+	for k, v := range options.Tags {
+		opts = append(opts, opentracing.Tag{Key: k, Value: v})
+	}
+
+	if !options.StartTime.IsZero() {
+		opts = append(opts, opentracing.StartTime(options.StartTime))
+	}
+
+	return opts
+}
+
+// Inject takes the composite SpanContext produced by a Multi tracer's
+// spans and injects each underlying SpanContext into carrier in turn. It's
+// best-effort: injection continues across all tracers even if one fails,
+// and any resulting errors are aggregated.
+func (t *tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	ctx, ok := sm.(*spanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+
+	var errs multiError
+	for i, tr := range t.tracers {
+		if ctx.contexts[i] == nil {
+			continue
+		}
+		if err := tr.Inject(ctx.contexts[i], format, carrier); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Extract tries each underlying tracer's Extract in order and returns the
+// first successful SpanContext, wrapped so that ChildOf(...) still produces
+// a valid (if freshly rooted) span in every other backend.
+func (t *tracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	var lastErr error = opentracing.ErrSpanContextNotFound
+
+	for i, tr := range t.tracers {
+		sc, err := tr.Extract(format, carrier)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		contexts := make([]opentracing.SpanContext, len(t.tracers))
+		contexts[i] = sc
+		return &spanContext{contexts: contexts}, nil
+	}
+
+	return nil, lastErr
+}
+
+// multiError aggregates the errors returned by the underlying tracers'
+// Inject calls.
+type multiError []error
+
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,53 @@
+package multi
+
+import (
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/tj/assert"
+)
+
+func TestStartSpanFansOut(t *testing.T) {
+	a, b := mocktracer.New(), mocktracer.New()
+	tracer := Multi(a, b)
+
+	span := tracer.StartSpan("op")
+	span.SetTag("tk", "tv")
+	span.Finish()
+
+	assert.Equal(t, 1, len(a.FinishedSpans()))
+	assert.Equal(t, 1, len(b.FinishedSpans()))
+	assert.Equal(t, "tv", a.FinishedSpans()[0].Tag("tk"))
+	assert.Equal(t, "tv", b.FinishedSpans()[0].Tag("tk"))
+}
+
+func TestParentChild(t *testing.T) {
+	a, b := mocktracer.New(), mocktracer.New()
+	tracer := Multi(a, b)
+
+	parent := tracer.StartSpan("parent")
+	child := tracer.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	child.Finish()
+	parent.Finish()
+
+	aParent, aChild := a.FinishedSpans()[1], a.FinishedSpans()[0]
+	assert.Equal(t, aParent.SpanContext.SpanID, aChild.ParentID)
+
+	bParent, bChild := b.FinishedSpans()[1], b.FinishedSpans()[0]
+	assert.Equal(t, bParent.SpanContext.SpanID, bChild.ParentID)
+}
+
+func TestInject(t *testing.T) {
+	a, b := mocktracer.New(), mocktracer.New()
+	tracer := Multi(a, b)
+
+	span := tracer.StartSpan("op")
+
+	carrier := opentracing.TextMapCarrier{}
+	err := tracer.Inject(span.Context(), opentracing.TextMap, carrier)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", carrier["mockpfx-ids-traceid"])
+
+	span.Finish()
+}
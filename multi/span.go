@@ -0,0 +1,138 @@
+package multi
+
+import (
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+// span is the composite opentracing.Span returned by a Multi tracer's
+// StartSpan. Every method forwards to each underlying span in order.
+type span struct {
+	tracer *tracer
+	spans  []opentracing.Span
+}
+
+func (s *span) Finish() {
+	for _, sp := range s.spans {
+		sp.Finish()
+	}
+}
+
+func (s *span) FinishWithOptions(opts opentracing.FinishOptions) {
+	for _, sp := range s.spans {
+		sp.FinishWithOptions(opts)
+	}
+}
+
+func (s *span) Context() opentracing.SpanContext {
+	contexts := make([]opentracing.SpanContext, len(s.spans))
+	for i, sp := range s.spans {
+		contexts[i] = sp.Context()
+	}
+	return &spanContext{contexts: contexts}
+}
+
+func (s *span) SetOperationName(operationName string) opentracing.Span {
+	for _, sp := range s.spans {
+		sp.SetOperationName(operationName)
+	}
+	return s
+}
+
+func (s *span) SetTag(key string, value interface{}) opentracing.Span {
+	for _, sp := range s.spans {
+		sp.SetTag(key, value)
+	}
+	return s
+}
+
+func (s *span) LogFields(fields ...otlog.Field) {
+	for _, sp := range s.spans {
+		sp.LogFields(fields...)
+	}
+}
+
+func (s *span) LogKV(alternatingKeyValues ...interface{}) {
+	for _, sp := range s.spans {
+		sp.LogKV(alternatingKeyValues...)
+	}
+}
+
+func (s *span) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	for _, sp := range s.spans {
+		sp.SetBaggageItem(restrictedKey, value)
+	}
+	return s
+}
+
+// BaggageItem returns the value held by the first underlying span that has
+// one set for restrictedKey.
+func (s *span) BaggageItem(restrictedKey string) string {
+	for _, sp := range s.spans {
+		if v := sp.BaggageItem(restrictedKey); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (s *span) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+// Deprecated: use LogFields or LogKV
+func (s *span) LogEvent(event string) {
+	for _, sp := range s.spans {
+		sp.LogEvent(event)
+	}
+}
+
+// Deprecated: use LogFields or LogKV
+func (s *span) LogEventWithPayload(event string, payload interface{}) {
+	for _, sp := range s.spans {
+		sp.LogEventWithPayload(event, payload)
+	}
+}
+
+// Deprecated: use LogFields or LogKV
+func (s *span) Log(data opentracing.LogData) {
+	for _, sp := range s.spans {
+		sp.Log(data)
+	}
+}
+
+// spanContext is the composite opentracing.SpanContext returned by span's
+// Context(). It holds one underlying SpanContext per tracer passed to
+// Multi, in the same order; an entry is nil if that tracer had no context
+// to contribute (e.g. it wasn't present in the carrier on Extract).
+type spanContext struct {
+	contexts []opentracing.SpanContext
+}
+
+// ForeachBaggageItem iterates the baggage of every underlying context,
+// skipping keys already seen in an earlier one.
+func (c *spanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	seen := map[string]bool{}
+	for _, ctx := range c.contexts {
+		if ctx == nil {
+			continue
+		}
+
+		var stop bool
+		ctx.ForeachBaggageItem(func(k, v string) bool {
+			if seen[k] {
+				return true
+			}
+			seen[k] = true
+
+			if !handler(k, v) {
+				stop = true
+				return false
+			}
+			return true
+		})
+		if stop {
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package tracing provides context.Context-based Start/Finish helpers and
+// gRPC/HTTP middleware built on top of opentracing.GlobalTracer(), in the
+// spirit of ipfs go-log's Start(ctx, name) and grpc-opentracing. It's
+// intended to sit on top of an apexlog.Tracer registered via
+// opentracing.SetGlobalTracer, but works with any opentracing.Tracer.
+package tracing
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+// Start begins a new Span named op as a child of any Span already active
+// in ctx, and returns a context carrying it. Pair with Finish or
+// FinishWithErr, typically via defer:
+//
+//	ctx = tracing.Start(ctx, "GetFeed")
+//	defer tracing.Finish(ctx)
+func Start(ctx context.Context, op string, opts ...opentracing.StartSpanOption) context.Context {
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+
+	span := opentracing.GlobalTracer().StartSpan(op, opts...)
+	return opentracing.ContextWithSpan(ctx, span)
+}
+
+// Finish finishes the Span active in ctx, if any.
+func Finish(ctx context.Context) {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.Finish()
+	}
+}
+
+// errSetter is implemented by *apexlog.Span; FinishWithErr uses it when
+// available so the resulting log entry is finalized at Error level.
+type errSetter interface {
+	SetError(err error) opentracing.Span
+}
+
+// FinishWithErr finishes the Span active in ctx, if any, first recording
+// err on it so the entry reflects the failure. Against an apexlog.Tracer
+// this finalizes the log line at Error level via Span.SetError; against
+// any other opentracing.Tracer it falls back to the "error" tag and an
+// "error.object" log field per OpenTracing convention.
+func FinishWithErr(ctx context.Context, err error) {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return
+	}
+	defer span.Finish()
+
+	if err == nil {
+		return
+	}
+
+	if setter, ok := span.(errSetter); ok {
+		setter.SetError(err)
+		return
+	}
+
+	span.SetTag("error", true)
+	span.LogFields(otlog.Error(err))
+}
@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/tj/assert"
+)
+
+func TestStartFinish(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	ctx := Start(context.Background(), "op")
+	assert.NotNil(t, opentracing.SpanFromContext(ctx))
+	Finish(ctx)
+
+	assert.Equal(t, 1, len(tracer.FinishedSpans()))
+}
+
+func TestFinishWithErrTagsError(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	ctx := Start(context.Background(), "op")
+	FinishWithErr(ctx, errors.New("boom"))
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, true, spans[0].Tag("error"))
+}
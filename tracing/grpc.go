@@ -0,0 +1,154 @@
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor starts a server-side Span for each unary RPC,
+// extracting any SpanContext from the incoming metadata and tagging the
+// span with the RPC's outcome once the handler returns.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer span.Finish()
+
+		resp, err := handler(ctx, req)
+		tagGRPCStatus(span, err)
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor starts a client-side Span for each unary RPC,
+// injecting its SpanContext into the outgoing metadata and tagging the
+// span with the RPC's outcome once the call returns.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, resp interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startClientSpan(ctx, method)
+		defer span.Finish()
+
+		err := invoker(ctx, method, req, resp, cc, opts...)
+		tagGRPCStatus(span, err)
+		return err
+	}
+}
+
+// StreamServerInterceptor starts a server-side Span for each streaming
+// RPC, extracting any SpanContext from the incoming metadata.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		defer span.Finish()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		tagGRPCStatus(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor starts a client-side Span for each streaming
+// RPC, injecting its SpanContext into the outgoing metadata. The span is
+// finished once the stream completes, i.e. on the first call to RecvMsg
+// that returns an error (including io.EOF).
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			tagGRPCStatus(span, err)
+			span.Finish()
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: stream, span: span}, nil
+	}
+}
+
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, opentracing.Span) {
+	tracer := opentracing.GlobalTracer()
+
+	var opts []opentracing.StartSpanOption
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if parentCtx, err := tracer.Extract(opentracing.TextMap, metadataTextMap(md)); err == nil {
+			opts = append(opts, opentracing.ChildOf(parentCtx))
+		}
+	}
+	opts = append(opts, ext.SpanKindRPCServer)
+
+	span := tracer.StartSpan(fullMethod, opts...)
+	ext.Component.Set(span, "grpc")
+
+	return opentracing.ContextWithSpan(ctx, span), span
+}
+
+func startClientSpan(ctx context.Context, fullMethod string) (context.Context, opentracing.Span) {
+	tracer := opentracing.GlobalTracer()
+
+	var opts []opentracing.StartSpanOption
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+	opts = append(opts, ext.SpanKindRPCClient)
+
+	span := tracer.StartSpan(fullMethod, opts...)
+	ext.Component.Set(span, "grpc")
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	_ = tracer.Inject(span.Context(), opentracing.TextMap, metadataTextMap(md))
+
+	return metadata.NewOutgoingContext(opentracing.ContextWithSpan(ctx, span), md), span
+}
+
+// tagGRPCStatus tags span with the RPC's outcome per
+// https://github.com/opentracing/specification/blob/master/semantic_conventions.md.
+func tagGRPCStatus(span opentracing.Span, err error) {
+	span.SetTag("grpc.code", status.Code(err).String())
+	if err != nil {
+		ext.Error.Set(span, true)
+	}
+}
+
+// serverStream overrides Context() so a streaming handler observes the
+// span started by StreamServerInterceptor.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// clientStream finishes span once the stream completes.
+type clientStream struct {
+	grpc.ClientStream
+	span opentracing.Span
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		return nil
+	}
+
+	if err == io.EOF {
+		s.span.SetTag("grpc.code", codes.OK.String())
+	} else {
+		tagGRPCStatus(s.span, err)
+	}
+	s.span.Finish()
+	return err
+}
@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/tj/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptorExtractsParentAndTagsStatus(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	parent := tracer.StartSpan("client")
+	md := metadata.MD{}
+	assert.Nil(t, tracer.Inject(parent.Context(), opentracing.TextMap, metadataTextMap(md)))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}
+
+	var sawSpan bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		sawSpan = opentracing.SpanFromContext(ctx) != nil
+		return "resp", nil
+	}
+
+	resp, err := interceptor(ctx, "req", info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "resp", resp)
+	assert.True(t, sawSpan)
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, parent.Context().(mocktracer.MockSpanContext).SpanID, spans[0].ParentID)
+	assert.Equal(t, codes.OK.String(), spans[0].Tag("grpc.code"))
+}
+
+func TestUnaryServerInterceptorTagsErrorStatus(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Internal, "boom")
+	}
+
+	_, err := interceptor(context.Background(), "req", info, handler)
+	assert.NotNil(t, err)
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, codes.Internal.String(), spans[0].Tag("grpc.code"))
+	assert.Equal(t, true, spans[0].Tag("error"))
+}
+
+func TestUnaryClientInterceptorInjectsOutgoingMetadata(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	interceptor := UnaryClientInterceptor()
+
+	var gotMD metadata.MD
+	invoker := func(ctx context.Context, method string, req, resp interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		gotMD, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	err := interceptor(context.Background(), "/svc/Method", "req", "resp", nil, invoker)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", gotMD.Get("mockpfx-ids-traceid")[0])
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, codes.OK.String(), spans[0].Tag("grpc.code"))
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptorExposesSpanOnContext(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	interceptor := StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/svc/Stream"}
+
+	var sawSpan bool
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		sawSpan = opentracing.SpanFromContext(ss.Context()) != nil
+		return nil
+	}
+
+	err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, handler)
+	assert.Nil(t, err)
+	assert.True(t, sawSpan)
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, codes.OK.String(), spans[0].Tag("grpc.code"))
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	recvErr error
+}
+
+func (s *fakeClientStream) RecvMsg(m interface{}) error { return s.recvErr }
+
+func TestStreamClientInterceptorFinishesOnEOF(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	interceptor := StreamClientInterceptor()
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{recvErr: io.EOF}, nil
+	}
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Stream", streamer)
+	assert.Nil(t, err)
+
+	assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+	assert.Equal(t, 1, len(tracer.FinishedSpans()))
+	assert.Equal(t, codes.OK.String(), tracer.FinishedSpans()[0].Tag("grpc.code"))
+}
+
+func TestStreamClientInterceptorFinishesOnRecvError(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	interceptor := StreamClientInterceptor()
+	recvErr := status.Error(codes.Unavailable, "down")
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{recvErr: recvErr}, nil
+	}
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Stream", streamer)
+	assert.Nil(t, err)
+
+	assert.Equal(t, recvErr, stream.RecvMsg(nil))
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, codes.Unavailable.String(), spans[0].Tag("grpc.code"))
+	assert.Equal(t, true, spans[0].Tag("error"))
+}
+
+func TestStreamClientInterceptorFinishesOnStreamerError(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	interceptor := StreamClientInterceptor()
+	wantErr := errors.New("dial failed")
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, wantErr
+	}
+
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Stream", streamer)
+	assert.Equal(t, wantErr, err)
+	assert.Equal(t, 1, len(tracer.FinishedSpans()))
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// Handler wraps next so every inbound request starts a server-side Span,
+// extracting any upstream SpanContext from the request headers. The span
+// is tagged per OpenTracing's HTTP semantic conventions and stashed in the
+// request's context, where next (or tracing.Start) can pick it up.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tracer := opentracing.GlobalTracer()
+
+		var opts []opentracing.StartSpanOption
+		if parentCtx, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(r.Header)); err == nil {
+			opts = append(opts, opentracing.ChildOf(parentCtx))
+		}
+		opts = append(opts, ext.SpanKindRPCServer)
+
+		span := tracer.StartSpan(r.Method+" "+r.URL.Path, opts...)
+		defer span.Finish()
+
+		ext.Component.Set(span, "net/http")
+		ext.HTTPMethod.Set(span, r.Method)
+		ext.HTTPUrl.Set(span, r.URL.String())
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(opentracing.ContextWithSpan(r.Context(), span)))
+
+		ext.HTTPStatusCode.Set(span, uint16(sw.status))
+		if sw.status >= http.StatusInternalServerError {
+			ext.Error.Set(span, true)
+		}
+	})
+}
+
+// statusWriter captures the status code written by the wrapped handler so
+// Handler can tag the span with it once ServeHTTP returns.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// RoundTripper wraps next so every outgoing request starts a client-side
+// Span, as a child of any Span active in the request's context, and
+// injects it into the outgoing request headers.
+func RoundTripper(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		tracer := opentracing.GlobalTracer()
+
+		var opts []opentracing.StartSpanOption
+		if parent := opentracing.SpanFromContext(r.Context()); parent != nil {
+			opts = append(opts, opentracing.ChildOf(parent.Context()))
+		}
+		opts = append(opts, ext.SpanKindRPCClient)
+
+		span := tracer.StartSpan(r.Method+" "+r.URL.Path, opts...)
+		defer span.Finish()
+
+		ext.Component.Set(span, "net/http")
+		ext.HTTPMethod.Set(span, r.Method)
+		ext.HTTPUrl.Set(span, r.URL.String())
+
+		r = r.Clone(r.Context())
+		_ = tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(r.Header))
+
+		resp, err := next.RoundTrip(r)
+		if err != nil {
+			ext.Error.Set(span, true)
+			return resp, err
+		}
+
+		ext.HTTPStatusCode.Set(span, uint16(resp.StatusCode))
+		if resp.StatusCode >= http.StatusInternalServerError {
+			ext.Error.Set(span, true)
+		}
+		return resp, nil
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/tj/assert"
+)
+
+func TestHandlerExtractsParentAndTagsStatus(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	parent := tracer.StartSpan("client")
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	assert.Nil(t, tracer.Inject(parent.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header)))
+
+	var sawSpan bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawSpan = opentracing.SpanFromContext(r.Context()) != nil
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	rec := httptest.NewRecorder()
+	Handler(next).ServeHTTP(rec, req)
+
+	assert.True(t, sawSpan)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, parent.Context().(mocktracer.MockSpanContext).SpanID, spans[0].ParentID)
+	assert.Equal(t, uint16(http.StatusCreated), spans[0].Tag("http.status_code"))
+	assert.Nil(t, spans[0].Tag("error"))
+}
+
+func TestHandlerTagsServerErrorStatus(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	Handler(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, true, spans[0].Tag("error"))
+}
+
+func TestRoundTripperInjectsHeaders(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	var gotHeader http.Header
+	next := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		gotHeader = r.Header
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	resp, err := RoundTripper(next).RoundTrip(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, "", gotHeader.Get("mockpfx-ids-traceid"))
+
+	spans := tracer.FinishedSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, uint16(http.StatusOK), spans[0].Tag("http.status_code"))
+}
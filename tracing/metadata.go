@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataTextMap adapts grpc metadata.MD to opentracing.TextMapReader and
+// opentracing.TextMapWriter, so Tracer.Inject/Extract can work directly
+// against outgoing/incoming gRPC metadata.
+type metadataTextMap metadata.MD
+
+func (m metadataTextMap) Set(key, val string) {
+	key = strings.ToLower(key)
+	metadata.MD(m)[key] = append(metadata.MD(m)[key], val)
+}
+
+func (m metadataTextMap) ForeachKey(handler func(key, val string) error) error {
+	for k, vs := range metadata.MD(m) {
+		for _, v := range vs {
+			if err := handler(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}